@@ -0,0 +1,97 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ArgoSetting represents the response from the Argo Smart Routing and Argo
+// Tiered Caching endpoints.
+type ArgoSetting struct {
+	ID         string    `json:"id"`
+	Value      string    `json:"value"`
+	ModifiedOn time.Time `json:"modified_on"`
+	Editable   bool      `json:"editable"`
+}
+
+// argoResponse represents the response from the Argo Smart Routing and Argo
+// Tiered Caching endpoints.
+type argoResponse struct {
+	Response
+	Result ArgoSetting `json:"result"`
+}
+
+// ArgoSmartRouting returns the current Argo Smart Routing setting for a zone.
+// API reference:
+// 	https://api.cloudflare.com/#argo-smart-routing-get-argo-smart-routing-setting
+// 	GET /zones/:id/argo/smart_routing
+func (api *API) ArgoSmartRouting(zoneID string) (ArgoSetting, error) {
+	uri := "/zones/" + zoneID + "/argo/smart_routing"
+	return api.getArgoSetting(uri)
+}
+
+// UpdateArgoSmartRouting updates the Argo Smart Routing setting for a zone.
+// API reference:
+// 	https://api.cloudflare.com/#argo-smart-routing-patch-argo-smart-routing-setting
+// 	PATCH /zones/:id/argo/smart_routing
+func (api *API) UpdateArgoSmartRouting(zoneID string, enabled bool) (ArgoSetting, error) {
+	uri := "/zones/" + zoneID + "/argo/smart_routing"
+	return api.updateArgoSetting(uri, enabled)
+}
+
+// ArgoTieredCaching returns the current Argo Tiered Caching setting for a zone.
+// API reference:
+// 	https://api.cloudflare.com/#argo-tiered-caching-get-argo-tiered-caching-setting
+// 	GET /zones/:id/argo/tiered_caching
+func (api *API) ArgoTieredCaching(zoneID string) (ArgoSetting, error) {
+	uri := "/zones/" + zoneID + "/argo/tiered_caching"
+	return api.getArgoSetting(uri)
+}
+
+// UpdateArgoTieredCaching updates the Argo Tiered Caching setting for a zone.
+// API reference:
+// 	https://api.cloudflare.com/#argo-tiered-caching-patch-argo-tiered-caching-setting
+// 	PATCH /zones/:id/argo/tiered_caching
+func (api *API) UpdateArgoTieredCaching(zoneID string, enabled bool) (ArgoSetting, error) {
+	uri := "/zones/" + zoneID + "/argo/tiered_caching"
+	return api.updateArgoSetting(uri, enabled)
+}
+
+// getArgoSetting fetches the current value of an Argo feature for the given
+// zone setting endpoint.
+func (api *API) getArgoSetting(uri string) (ArgoSetting, error) {
+	res, err := api.makeRequest("GET", uri, nil)
+	if err != nil {
+		return ArgoSetting{}, errors.Wrap(err, errMakeRequestError)
+	}
+	var r argoResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return ArgoSetting{}, errors.Wrap(err, errUnmarshalError)
+	}
+	return r.Result, nil
+}
+
+// updateArgoSetting turns an Argo feature on or off for the given zone setting
+// endpoint.
+func (api *API) updateArgoSetting(uri string, enabled bool) (ArgoSetting, error) {
+	value := "off"
+	if enabled {
+		value = "on"
+	}
+	params := struct {
+		Value string `json:"value"`
+	}{
+		Value: value,
+	}
+	res, err := api.makeRequest("PATCH", uri, params)
+	if err != nil {
+		return ArgoSetting{}, errors.Wrap(err, errMakeRequestError)
+	}
+	var r argoResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return ArgoSetting{}, errors.Wrap(err, errUnmarshalError)
+	}
+	return r.Result, nil
+}
@@ -0,0 +1,163 @@
+package cloudflare
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readBody reads and returns the raw request body, for asserting on the
+// wire shape of PATCH payloads.
+func readBody(t *testing.T, r *http.Request) string {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("reading request body: %v", err)
+	}
+	return string(b)
+}
+
+func TestArgoSmartRouting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/argo/smart_routing", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "smart_routing",
+				"value": "on",
+				"modified_on": "2014-01-01T05:20:00.12345Z",
+				"editable": true
+			}
+		}`)
+	})
+
+	want := ArgoSetting{
+		ID:         "smart_routing",
+		Value:      "on",
+		ModifiedOn: time.Date(2014, 1, 1, 5, 20, 0, 123450000, time.UTC),
+		Editable:   true,
+	}
+
+	actual, err := client.ArgoSmartRouting("foo")
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, actual)
+	}
+}
+
+func TestUpdateArgoSmartRouting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/argo/smart_routing", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, `{"value":"on"}`, readBody(t, r))
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "smart_routing",
+				"value": "on",
+				"modified_on": "2014-01-01T05:20:00.12345Z",
+				"editable": true
+			}
+		}`)
+	})
+
+	actual, err := client.UpdateArgoSmartRouting("foo", true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "on", actual.Value)
+	}
+}
+
+func TestUpdateArgoSmartRoutingDisable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/argo/smart_routing", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, `{"value":"off"}`, readBody(t, r))
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "smart_routing",
+				"value": "off",
+				"modified_on": "2014-01-01T05:20:00.12345Z",
+				"editable": true
+			}
+		}`)
+	})
+
+	actual, err := client.UpdateArgoSmartRouting("foo", false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "off", actual.Value)
+	}
+}
+
+func TestArgoTieredCaching(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/argo/tiered_caching", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "tiered_caching",
+				"value": "on",
+				"modified_on": "2014-01-01T05:20:00.12345Z",
+				"editable": true
+			}
+		}`)
+	})
+
+	want := ArgoSetting{
+		ID:         "tiered_caching",
+		Value:      "on",
+		ModifiedOn: time.Date(2014, 1, 1, 5, 20, 0, 123450000, time.UTC),
+		Editable:   true,
+	}
+
+	actual, err := client.ArgoTieredCaching("foo")
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, actual)
+	}
+}
+
+func TestUpdateArgoTieredCaching(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/argo/tiered_caching", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, `{"value":"on"}`, readBody(t, r))
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "tiered_caching",
+				"value": "on",
+				"modified_on": "2014-01-01T05:20:00.12345Z",
+				"editable": true
+			}
+		}`)
+	})
+
+	actual, err := client.UpdateArgoTieredCaching("foo", true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "on", actual.Value)
+	}
+}
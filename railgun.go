@@ -2,7 +2,12 @@ package cloudflare
 
 import (
 	"encoding/json"
+	"math"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -31,6 +36,9 @@ type Railgun struct {
 // RailgunListOptions represents the parameters used to list railguns.
 type RailgunListOptions struct {
 	Direction string
+	Order     string
+	Page      int
+	PerPage   int
 }
 
 // railgunResponse represents the response from the Create Railgun and the Railgun Details endpoints.
@@ -42,7 +50,8 @@ type railgunResponse struct {
 // railgunsResponse represents the response from the List Railguns endpoint.
 type railgunsResponse struct {
 	Response
-	Result []Railgun `json:"result"`
+	Result     []Railgun  `json:"result"`
+	ResultInfo ResultInfo `json:"result_info"`
 }
 
 // CreateRailgun creates a new Railgun.
@@ -76,23 +85,67 @@ func (api *API) CreateRailgun(name string, org Organization) (Railgun, error) {
 //  https://api.cloudflare.com/#railgun-list-railguns
 //  GET /railguns
 func (api *API) ListRailguns(options RailgunListOptions, org Organization) ([]Railgun, error) {
+	railguns, _, err := api.ListRailgunsPaginated(options, org)
+	return railguns, err
+}
+
+// ListRailgunsPaginated lists Railguns connected to an account, along with
+// the result metadata describing the page that was fetched. Use EachRailgun
+// to walk every page automatically.
+// API reference:
+//  https://api.cloudflare.com/#railgun-list-railguns
+//  GET /railguns
+func (api *API) ListRailgunsPaginated(options RailgunListOptions, org Organization) ([]Railgun, ResultInfo, error) {
 	v := url.Values{}
 	if options.Direction != "" {
 		v.Set("direction", options.Direction)
 	}
+	if options.Order != "" {
+		v.Set("order", options.Order)
+	}
+	if options.Page > 0 {
+		v.Set("page", strconv.Itoa(options.Page))
+	}
+	if options.PerPage > 0 {
+		v.Set("per_page", strconv.Itoa(options.PerPage))
+	}
 	uri := "/railguns" + "?" + v.Encode()
 	if org.ID != "" {
 		uri = "/organizations/" + org.ID + uri
 	}
 	res, err := api.makeRequest("GET", uri, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, errMakeRequestError)
+		return nil, ResultInfo{}, errors.Wrap(err, errMakeRequestError)
 	}
 	var r railgunsResponse
 	if err := json.Unmarshal(res, &r); err != nil {
-		return nil, errors.Wrap(err, errUnmarshalError)
+		return nil, ResultInfo{}, errors.Wrap(err, errUnmarshalError)
+	}
+	return r.Result, r.ResultInfo, nil
+}
+
+// EachRailgun calls fn once for every Railgun connected to an account,
+// transparently walking all pages of the List Railguns endpoint. Iteration
+// stops at the first error returned by fn or encountered while paginating.
+func (api *API) EachRailgun(options RailgunListOptions, org Organization, fn func(Railgun) error) error {
+	if options.Page <= 0 {
+		options.Page = 1
+	}
+	for {
+		railguns, info, err := api.ListRailgunsPaginated(options, org)
+		if err != nil {
+			return err
+		}
+		for _, railgun := range railguns {
+			if err := fn(railgun); err != nil {
+				return err
+			}
+		}
+		if info.TotalPages <= info.Page {
+			return nil
+		}
+		options.Page = info.Page + 1
 	}
-	return r.Result, nil
 }
 
 // RailgunDetails returns the details for a Railgun.
@@ -235,6 +288,85 @@ type RailgunDiagnosis struct {
 	CFCacheStatus string `json:"cf-cache-status"`
 }
 
+// RailgunDiagnosisParsed holds the elapsed_time, body_size and
+// missing_headers fields of a RailgunDiagnosis parsed into their natural Go
+// types, plus a derived Healthy flag. The other RailgunDiagnosis fields are
+// not carried over.
+type RailgunDiagnosisParsed struct {
+	ElapsedTime    time.Duration
+	BodySize       int64
+	MissingHeaders []string
+	Healthy        bool
+}
+
+// Parse converts a RailgunDiagnosis into its typed form. Healthy reports
+// whether the diagnosed connection looked good: an HTTP 200, no CFWANError,
+// and no forced connection close.
+func (d RailgunDiagnosis) Parse() (RailgunDiagnosisParsed, error) {
+	elapsedTime, err := parseRailgunElapsedTime(d.ElapsedTime)
+	if err != nil {
+		return RailgunDiagnosisParsed{}, errors.Wrap(err, "parsing elapsed_time")
+	}
+
+	bodySize, err := parseRailgunBodySize(d.BodySize)
+	if err != nil {
+		return RailgunDiagnosisParsed{}, errors.Wrap(err, "parsing body_size")
+	}
+
+	var missingHeaders []string
+	if d.MissingHeaders != "" {
+		for _, header := range strings.Split(d.MissingHeaders, ",") {
+			missingHeaders = append(missingHeaders, strings.TrimSpace(header))
+		}
+	}
+
+	return RailgunDiagnosisParsed{
+		ElapsedTime:    elapsedTime,
+		BodySize:       bodySize,
+		MissingHeaders: missingHeaders,
+		Healthy:        d.HTTPStatus == http.StatusOK && d.CFWANError == "" && !d.ConnectionClose,
+	}, nil
+}
+
+// parseRailgunElapsedTime parses the elapsed_time field of a RailgunDiagnosis,
+// which the API may render either as a Go-style duration string (e.g. "80ms")
+// or as a bare fractional number of seconds (e.g. "0.08"). A bare integer
+// (e.g. "80") is rejected rather than guessed at: CloudFlare's docs don't
+// define this field's unit-less format, and silently assuming seconds would
+// turn an unlabelled millisecond count into a duration three orders of
+// magnitude too large.
+func parseRailgunElapsedTime(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		if seconds == math.Trunc(seconds) {
+			return 0, errors.Errorf("ambiguous elapsed time format (bare integer, unit unknown): %q", s)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return 0, errors.Errorf("unrecognised elapsed time format: %q", s)
+}
+
+// parseRailgunBodySize parses the body_size field of a RailgunDiagnosis,
+// which the API may render as a bare byte count or as a count followed by a
+// unit (e.g. "1234 bytes").
+func parseRailgunBodySize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	size, err := strconv.ParseInt(strings.Fields(s)[0], 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("unrecognised body size format: %q", s)
+	}
+	return size, nil
+}
+
 // railgunDiagnosisResponse represents the response from the Test Railgun Connection enpoint.
 type railgunDiagnosisResponse struct {
 	Response
@@ -292,6 +424,20 @@ func (api *API) TestRailgunConnection(zoneID, railgunID string) (RailgunDiagnosi
 	return r.Result, nil
 }
 
+// TestRailgunConnectionParsed tests a Railgun connection for a given zone,
+// like TestRailgunConnection, but returns the typed RailgunDiagnosisParsed
+// form so callers don't have to reparse its string fields.
+// API reference:
+//  https://api.cloudflare.com/#railgun-connections-for-a-zone-test-railgun-connection
+//  GET /zones/:zone_identifier/railguns/:identifier/diagnose
+func (api *API) TestRailgunConnectionParsed(zoneID, railgunID string) (RailgunDiagnosisParsed, error) {
+	diagnosis, err := api.TestRailgunConnection(zoneID, railgunID)
+	if err != nil {
+		return RailgunDiagnosisParsed{}, err
+	}
+	return diagnosis.Parse()
+}
+
 // connectZoneRailgun connects (true) or disconnects (false) a Railgun for a given zone.
 // API reference:
 //  https://api.cloudflare.com/#railguns-for-a-zone-connect-or-disconnect-a-railgun
@@ -329,3 +475,83 @@ func (api *API) ConnectZoneRailgun(zoneID, railgunID string) (ZoneRailgun, error
 func (api *API) DisconnectZoneRailgun(zoneID, railgunID string) (ZoneRailgun, error) {
 	return api.connectZoneRailgun(zoneID, railgunID, false)
 }
+
+// RailgunZoneDiagnosis pairs a zone with the diagnosis (or error) returned
+// when testing its Railgun connection.
+type RailgunZoneDiagnosis struct {
+	ZoneID    string
+	ZoneName  string
+	Diagnosis RailgunDiagnosis
+	Error     error
+}
+
+// RailgunDiagnosisErrors collects the per-zone errors encountered while
+// diagnosing a Railgun across multiple zones. It implements error so a
+// failed sweep can still return its partial results alongside a single
+// combined error.
+type RailgunDiagnosisErrors []error
+
+func (e RailgunDiagnosisErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// DiagnoseRailgunAllZones tests the Railgun connection for every zone
+// connected to railgunID, running up to concurrency diagnoses in parallel.
+// The returned slice contains one RailgunZoneDiagnosis per zone, in the
+// order reported by RailgunZones, even when some zones fail; any per-zone
+// failures are also collected and returned as a RailgunDiagnosisErrors.
+func (api *API) DiagnoseRailgunAllZones(railgunID string, org Organization, concurrency int) ([]RailgunZoneDiagnosis, error) {
+	zones, err := api.RailgunZones(railgunID, org)
+	if err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	type job struct {
+		index int
+		zone  Zone
+	}
+	jobs := make(chan job)
+	go func() {
+		for i, zone := range zones {
+			jobs <- job{index: i, zone: zone}
+		}
+		close(jobs)
+	}()
+
+	results := make([]RailgunZoneDiagnosis, len(zones))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				diagnosis, err := api.TestRailgunConnection(j.zone.ID, railgunID)
+				results[j.index] = RailgunZoneDiagnosis{
+					ZoneID:    j.zone.ID,
+					ZoneName:  j.zone.Name,
+					Diagnosis: diagnosis,
+					Error:     err,
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var errs RailgunDiagnosisErrors
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, errors.Wrapf(result.Error, "zone %s (%s)", result.ZoneName, result.ZoneID))
+		}
+	}
+	if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
@@ -0,0 +1,279 @@
+package cloudflare
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRailgunElapsedTime(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "empty", in: "", want: 0},
+		{name: "go duration", in: "80ms", want: 80 * time.Millisecond},
+		{name: "fractional seconds", in: "0.08", want: 80 * time.Millisecond},
+		{name: "bare integer is ambiguous", in: "80", wantErr: true},
+		{name: "garbage", in: "not-a-duration", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRailgunElapsedTime(tc.in)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseRailgunBodySize(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{name: "empty", in: "", want: 0},
+		{name: "bare bytes", in: "1234", want: 1234},
+		{name: "unit suffixed", in: "1234 bytes", want: 1234},
+		{name: "garbage", in: "lots", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRailgunBodySize(tc.in)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDiagnoseRailgunAllZones(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/railguns/foo/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "zone-good", "name": "good.example.com"},
+				{"id": "zone-bad", "name": "bad.example.com"}
+			]
+		}`)
+	})
+
+	mux.HandleFunc("/zones/zone-good/railguns/foo/diagnose", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {"http_status": 200, "elapsed_time": "80ms"}
+		}`)
+	})
+
+	mux.HandleFunc("/zones/zone-bad/railguns/foo/diagnose", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{
+			"success": false,
+			"errors": [{"code": 1000, "message": "railgun diagnosis failed"}],
+			"messages": [],
+			"result": null
+		}`)
+	})
+
+	results, err := client.DiagnoseRailgunAllZones("foo", Organization{}, 2)
+	assert.Error(t, err)
+
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "zone-good", results[0].ZoneID)
+		assert.NoError(t, results[0].Error)
+		assert.Equal(t, 200, results[0].Diagnosis.HTTPStatus)
+
+		assert.Equal(t, "zone-bad", results[1].ZoneID)
+		assert.Error(t, results[1].Error)
+	}
+
+	diagErrs, ok := err.(RailgunDiagnosisErrors)
+	if assert.True(t, ok) {
+		assert.Len(t, diagErrs, 1)
+	}
+}
+
+func TestEachRailgun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"1": `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "1", "name": "railgun-1"},
+				{"id": "2", "name": "railgun-2"}
+			],
+			"result_info": {"page": 1, "per_page": 2, "count": 2, "total_count": 5, "total_pages": 3}
+		}`,
+		"2": `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "3", "name": "railgun-3"},
+				{"id": "4", "name": "railgun-4"}
+			],
+			"result_info": {"page": 2, "per_page": 2, "count": 2, "total_count": 5, "total_pages": 3}
+		}`,
+		"3": `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "5", "name": "railgun-5"}
+			],
+			"result_info": {"page": 3, "per_page": 2, "count": 1, "total_count": 5, "total_pages": 3}
+		}`,
+	}
+
+	mux.HandleFunc("/railguns", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		fmt.Fprint(w, pages[page])
+	})
+
+	var seen []string
+	err := client.EachRailgun(RailgunListOptions{PerPage: 2}, Organization{}, func(rg Railgun) error {
+		seen = append(seen, rg.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, seen)
+}
+
+func TestEachRailgunStopsOnCallbackError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/railguns", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "1", "name": "railgun-1"},
+				{"id": "2", "name": "railgun-2"}
+			],
+			"result_info": {"page": 1, "per_page": 2, "count": 2, "total_count": 4, "total_pages": 2}
+		}`)
+	})
+
+	wantErr := errors.New("stop")
+	err := client.EachRailgun(RailgunListOptions{PerPage: 2}, Organization{}, func(rg Railgun) error {
+		calls++
+		return wantErr
+	})
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRailgunDiagnosisParse(t *testing.T) {
+	base := RailgunDiagnosis{
+		HTTPStatus:      http.StatusOK,
+		ElapsedTime:     "80ms",
+		BodySize:        "1234 bytes",
+		MissingHeaders:  "X-Foo, X-Bar",
+		ConnectionClose: false,
+		CFWANError:      "",
+	}
+
+	cases := []struct {
+		name        string
+		mutate      func(d *RailgunDiagnosis)
+		wantHealthy bool
+	}{
+		{name: "healthy", mutate: func(d *RailgunDiagnosis) {}, wantHealthy: true},
+		{name: "unhealthy http status", mutate: func(d *RailgunDiagnosis) {
+			d.HTTPStatus = http.StatusInternalServerError
+		}, wantHealthy: false},
+		{name: "unhealthy cf-wan-error", mutate: func(d *RailgunDiagnosis) {
+			d.CFWANError = "522"
+		}, wantHealthy: false},
+		{name: "unhealthy connection close", mutate: func(d *RailgunDiagnosis) {
+			d.ConnectionClose = true
+		}, wantHealthy: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := base
+			tc.mutate(&d)
+
+			parsed, err := d.Parse()
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.wantHealthy, parsed.Healthy)
+				assert.Equal(t, 80*time.Millisecond, parsed.ElapsedTime)
+				assert.Equal(t, int64(1234), parsed.BodySize)
+				assert.Equal(t, []string{"X-Foo", "X-Bar"}, parsed.MissingHeaders)
+			}
+		})
+	}
+
+	t.Run("no missing headers", func(t *testing.T) {
+		d := base
+		d.MissingHeaders = ""
+
+		parsed, err := d.Parse()
+		if assert.NoError(t, err) {
+			assert.Nil(t, parsed.MissingHeaders)
+		}
+	})
+}
+
+func TestTestRailgunConnectionParsed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/foo/railguns/bar/diagnose", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"http_status": 200,
+				"elapsed_time": "80ms",
+				"body_size": "1234 bytes",
+				"missing_headers": "X-Foo, X-Bar",
+				"connection_close": false,
+				"cf-wan-error": ""
+			}
+		}`)
+	})
+
+	parsed, err := client.TestRailgunConnectionParsed("foo", "bar")
+	if assert.NoError(t, err) {
+		assert.True(t, parsed.Healthy)
+		assert.Equal(t, 80*time.Millisecond, parsed.ElapsedTime)
+		assert.Equal(t, int64(1234), parsed.BodySize)
+		assert.Equal(t, []string{"X-Foo", "X-Bar"}, parsed.MissingHeaders)
+	}
+}